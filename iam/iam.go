@@ -3,6 +3,7 @@
 package iam
 
 import (
+	"context"
 	"encoding/xml"
 	"launchpad.net/goamz/aws"
 	"net/http"
@@ -15,31 +16,103 @@ import (
 type IAM struct {
 	aws.Auth
 	aws.Region
+
+	// Client is the http.Client used to make requests. If nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+
+	// UseQuerySignature selects the legacy Signature Version 2
+	// query-string signing used by the original IAM endpoints, instead
+	// of the Signature Version 4 signing used by default. Set this to
+	// true to interoperate with callers that still depend on the v2
+	// behaviour.
+	UseQuerySignature bool
+
+	// RetryConfig controls how failed requests are retried. The zero
+	// value uses DefaultRetryConfig.
+	RetryConfig RetryConfig
 }
 
 // New creates a new IAM instance.
 func New(auth aws.Auth, region aws.Region) *IAM {
-	return &IAM{auth, region}
+	return &IAM{Auth: auth, Region: region}
+}
+
+func (iam *IAM) httpClient() *http.Client {
+	if iam.Client != nil {
+		return iam.Client
+	}
+	return http.DefaultClient
 }
 
 func (iam *IAM) query(params map[string]string, resp interface{}) error {
+	return iam.queryCtx(context.Background(), params, resp)
+}
+
+// queryCtx performs an IAM request, retrying throttling errors and
+// transient 5xx responses with full-jitter exponential backoff per
+// iam.RetryConfig. ctx bounds the total time spent across all attempts.
+func (iam *IAM) queryCtx(ctx context.Context, params map[string]string, resp interface{}) error {
 	params["Version"] = "2010-05-08"
-	params["Timestamp"] = time.Now().In(time.UTC).Format(time.RFC3339)
+	rc := iam.retryConfig()
+
+	for attempt := 0; ; attempt++ {
+		r, err := iam.do(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if r.StatusCode >= 300 {
+			ierr := buildError(r)
+			r.Body.Close()
+			if attempt+1 < rc.MaxAttempts && rc.isRetryable(ierr) {
+				if werr := waitBackoff(ctx, rc, attempt); werr != nil {
+					return werr
+				}
+				continue
+			}
+			return ierr
+		}
+
+		err = xml.NewDecoder(r.Body).Decode(resp)
+		r.Body.Close()
+		return err
+	}
+}
+
+// do builds, signs, and sends a single IAM request attempt.
+func (iam *IAM) do(ctx context.Context, params map[string]string) (*http.Response, error) {
+	now := time.Now().In(time.UTC)
+	p := make(map[string]string, len(params))
+	for k, v := range params {
+		p[k] = v
+	}
+	p["Timestamp"] = now.Format(time.RFC3339)
+
 	endpoint, err := url.Parse(iam.IAMEndpoint)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	var authHeader string
+	if iam.UseQuerySignature {
+		sign(iam.Auth, "GET", "/", p, endpoint.Host)
+	} else {
+		authHeader = signV4(iam.Auth, iam.Region.Name, "GET", "/", p, endpoint.Host, now)
 	}
-	sign(iam.Auth, "GET", "/", params, endpoint.Host)
-	endpoint.RawQuery = multimap(params).Encode()
-	r, err := http.Get(endpoint.String())
+	endpoint.RawQuery = canonicalQueryString(p)
+
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer r.Body.Close()
-	if r.StatusCode > 200 {
-		return buildError(r)
+	req = req.WithContext(ctx)
+	if authHeader != "" {
+		req.Header.Set("X-Amz-Date", now.Format(iso8601BasicFormat))
+		req.Header.Set("Authorization", authHeader)
 	}
-	return xml.NewDecoder(r.Body).Decode(resp)
+
+	return iam.httpClient().Do(req)
 }
 
 func buildError(r *http.Response) error {
@@ -58,12 +131,22 @@ func buildError(r *http.Response) error {
 	return &err
 }
 
-func multimap(p map[string]string) url.Values {
-	q := make(url.Values, len(p))
-	for k, v := range p {
-		q[k] = []string{v}
+// addPagination adds the Marker and MaxItems parameters used by IAM's
+// List* actions to paginate a result set. A zero maxItems leaves
+// MaxItems unset, letting IAM pick its default page size.
+func addPagination(params map[string]string, marker string, maxItems int) {
+	if marker != "" {
+		params["Marker"] = marker
+	}
+	if maxItems > 0 {
+		params["MaxItems"] = strconv.Itoa(maxItems)
 	}
-	return q
+}
+
+// SimpleResp is the response to IAM requests that return nothing beyond the
+// standard response metadata.
+type SimpleResp struct {
+	RequestId string `xml:"ResponseMetadata>RequestId"`
 }
 
 // Response to a CreateUser request.
@@ -78,21 +161,41 @@ type CreateUserResp struct {
 //
 // See http://goo.gl/BwIQ3 for more details.
 type User struct {
-	Arn  string
-	Path string
-	Id   string `xml:"UserId"`
-	Name string `xml:"UserName"`
+	Arn                 string
+	Path                string
+	Id                  string `xml:"UserId"`
+	Name                string `xml:"UserName"`
+	Tags                []Tag  `xml:"Tags>member"`
+	PermissionsBoundary PermissionsBoundary
+}
+
+// PermissionsBoundary describes the managed policy used as the
+// permissions boundary for a user or role.
+//
+// See http://goo.gl/0Q2fX for more details.
+type PermissionsBoundary struct {
+	PermissionsBoundaryType string
+	PermissionsBoundaryArn  string
 }
 
-// CreateUser creates a new user in IAM.
+// CreateUser creates a new user in IAM. tags may be nil, and
+// permissionsBoundary may be left empty to create the user with no
+// permissions boundary.
 //
 // See http://goo.gl/JS9Gz for more details.
-func (iam *IAM) CreateUser(name, path string) (*CreateUserResp, error) {
+func (iam *IAM) CreateUser(name, path string, tags []Tag, permissionsBoundary string) (*CreateUserResp, error) {
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"Action":   "CreateUser",
 		"Path":     path,
 		"UserName": name,
 	}
+	if permissionsBoundary != "" {
+		params["PermissionsBoundary"] = permissionsBoundary
+	}
+	addTagParams(params, tags)
 	resp := new(CreateUserResp)
 	if err := iam.query(params, resp); err != nil {
 		return nil, err
@@ -100,33 +203,1268 @@ func (iam *IAM) CreateUser(name, path string) (*CreateUserResp, error) {
 	return resp, nil
 }
 
-// Response to a CreateAccessKey request.
+// DeleteUser deletes a user from IAM. The user must not belong to any
+// groups, have any attached policies, or own any access keys.
 //
-// See http://goo.gl/L46Py for more details.
-type CreateAccessKeyResp struct {
-	AccessKey AccessKey `xml:"CreateAccessKeyResult>AccessKey"`
-	RequestId string    `xml:"ResponseMetadata>RequestId"`
+// See http://goo.gl/5sbkZ for more details.
+func (iam *IAM) DeleteUser(name string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":   "DeleteUser",
+		"UserName": name,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-// AccessKey encapsulates an access key generated for a user.
+// Response to a GetUser request.
 //
-// See http://goo.gl/LHgZR for more details.
-type AccessKey struct {
-	User   string `xml:"UserName"`
-	Id     string `xml:"AccessKeyId"`
-	Secret string `xml:"SecretAccessKey"`
-	Status string
+// See http://goo.gl/ZmlXC for more details.
+type GetUserResp struct {
+	User      User   `xml:"GetUserResult>User"`
+	RequestId string `xml:"ResponseMetadata>RequestId"`
 }
 
-// CreateAccessKey creates a new access key in IAM.
+// GetUser returns details about the user identified by name. If name is
+// empty, the user making the request is returned.
 //
-// See http://goo.gl/L46Py for more details.
-func (iam *IAM) CreateAccessKey(userName string) (*CreateAccessKeyResp, error) {
+// See http://goo.gl/ZmlXC for more details.
+func (iam *IAM) GetUser(name string) (*GetUserResp, error) {
+	params := map[string]string{"Action": "GetUser"}
+	if name != "" {
+		params["UserName"] = name
+	}
+	resp := new(GetUserResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a ListUsers request.
+//
+// See http://goo.gl/BwRvM for more details.
+type ListUsersResp struct {
+	Users       []User `xml:"ListUsersResult>Users>member"`
+	IsTruncated bool   `xml:"ListUsersResult>IsTruncated"`
+	Marker      string `xml:"ListUsersResult>Marker"`
+	RequestId   string `xml:"ResponseMetadata>RequestId"`
+}
+
+// ListUsers lists the users that have the given path prefix. If pathPrefix
+// is empty, it defaults to "/", which lists all users. marker resumes a
+// previous truncated listing, and maxItems bounds the page size; a zero
+// maxItems lets IAM pick its default.
+//
+// See http://goo.gl/BwRvM for more details.
+func (iam *IAM) ListUsers(pathPrefix, marker string, maxItems int) (*ListUsersResp, error) {
+	return iam.ListUsersCtx(context.Background(), pathPrefix, marker, maxItems)
+}
+
+// ListUsersCtx is ListUsers, bounding total request latency, including
+// retries, by ctx.
+//
+// See http://goo.gl/BwRvM for more details.
+func (iam *IAM) ListUsersCtx(ctx context.Context, pathPrefix, marker string, maxItems int) (*ListUsersResp, error) {
+	params := map[string]string{"Action": "ListUsers"}
+	if pathPrefix != "" {
+		params["PathPrefix"] = pathPrefix
+	}
+	addPagination(params, marker, maxItems)
+	resp := new(ListUsersResp)
+	if err := iam.queryCtx(ctx, params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EachUser calls fn for every user with the given path prefix, transparently
+// following IAM's Marker pagination until the full result set has been
+// visited. Iteration stops at the first error, whether from the IAM
+// request or from fn itself.
+func (iam *IAM) EachUser(pathPrefix string, fn func(User) error) error {
+	return iam.EachUserCtx(context.Background(), pathPrefix, fn)
+}
+
+// EachUserCtx is EachUser, bounding total iteration latency, including
+// retries across every page, by ctx.
+func (iam *IAM) EachUserCtx(ctx context.Context, pathPrefix string, fn func(User) error) error {
+	marker := ""
+	for {
+		resp, err := iam.ListUsersCtx(ctx, pathPrefix, marker, 0)
+		if err != nil {
+			return err
+		}
+		for _, u := range resp.Users {
+			if err := fn(u); err != nil {
+				return err
+			}
+		}
+		if !resp.IsTruncated {
+			return nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// UpdateUser updates the name and/or path of the user identified by name.
+// Either newName or newPath may be left empty to leave that attribute
+// unchanged.
+//
+// See http://goo.gl/kqWBQ for more details.
+func (iam *IAM) UpdateUser(name, newName, newPath string) (*SimpleResp, error) {
 	params := map[string]string{
-		"Action":   "CreateAccessKey",
-		"UserName": userName,
+		"Action":   "UpdateUser",
+		"UserName": name,
 	}
-	resp := new(CreateAccessKeyResp)
+	if newName != "" {
+		params["NewUserName"] = newName
+	}
+	if newPath != "" {
+		params["NewPath"] = newPath
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Group encapsulates a group managed by IAM.
+//
+// See http://goo.gl/sZS9r for more details.
+type Group struct {
+	Arn  string
+	Path string
+	Id   string `xml:"GroupId"`
+	Name string `xml:"GroupName"`
+}
+
+// Response to a CreateGroup request.
+//
+// See http://goo.gl/kd0Tm for more details.
+type CreateGroupResp struct {
+	Group     Group  `xml:"CreateGroupResult>Group"`
+	RequestId string `xml:"ResponseMetadata>RequestId"`
+}
+
+// CreateGroup creates a new group in IAM.
+//
+// See http://goo.gl/kd0Tm for more details.
+func (iam *IAM) CreateGroup(name, path string) (*CreateGroupResp, error) {
+	params := map[string]string{
+		"Action":    "CreateGroup",
+		"GroupName": name,
+	}
+	if path != "" {
+		params["Path"] = path
+	}
+	resp := new(CreateGroupResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteGroup deletes a group from IAM. The group must not contain any
+// users or have any attached policies.
+//
+// See http://goo.gl/sFBxy for more details.
+func (iam *IAM) DeleteGroup(name string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":    "DeleteGroup",
+		"GroupName": name,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a GetGroup request.
+//
+// See http://goo.gl/ZnacE for more details.
+type GetGroupResp struct {
+	Group       Group  `xml:"GetGroupResult>Group"`
+	Users       []User `xml:"GetGroupResult>Users>member"`
+	IsTruncated bool   `xml:"GetGroupResult>IsTruncated"`
+	Marker      string `xml:"GetGroupResult>Marker"`
+	RequestId   string `xml:"ResponseMetadata>RequestId"`
+}
+
+// GetGroup returns details about the group identified by name, along with
+// a page of the users that belong to it. marker resumes a previous
+// truncated listing, and maxItems bounds the page size; a zero maxItems
+// lets IAM pick its default.
+//
+// See http://goo.gl/ZnacE for more details.
+func (iam *IAM) GetGroup(name, marker string, maxItems int) (*GetGroupResp, error) {
+	return iam.GetGroupCtx(context.Background(), name, marker, maxItems)
+}
+
+// GetGroupCtx is GetGroup, bounding total request latency, including
+// retries, by ctx.
+//
+// See http://goo.gl/ZnacE for more details.
+func (iam *IAM) GetGroupCtx(ctx context.Context, name, marker string, maxItems int) (*GetGroupResp, error) {
+	params := map[string]string{
+		"Action":    "GetGroup",
+		"GroupName": name,
+	}
+	addPagination(params, marker, maxItems)
+	resp := new(GetGroupResp)
+	if err := iam.queryCtx(ctx, params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a ListGroups request.
+//
+// See http://goo.gl/BwcOT for more details.
+type ListGroupsResp struct {
+	Groups      []Group `xml:"ListGroupsResult>Groups>member"`
+	IsTruncated bool    `xml:"ListGroupsResult>IsTruncated"`
+	Marker      string  `xml:"ListGroupsResult>Marker"`
+	RequestId   string  `xml:"ResponseMetadata>RequestId"`
+}
+
+// ListGroups lists the groups that have the given path prefix. If
+// pathPrefix is empty, it defaults to "/", which lists all groups.
+// marker resumes a previous truncated listing, and maxItems bounds the
+// page size; a zero maxItems lets IAM pick its default.
+//
+// See http://goo.gl/BwcOT for more details.
+func (iam *IAM) ListGroups(pathPrefix, marker string, maxItems int) (*ListGroupsResp, error) {
+	return iam.ListGroupsCtx(context.Background(), pathPrefix, marker, maxItems)
+}
+
+// ListGroupsCtx is ListGroups, bounding total request latency, including
+// retries, by ctx.
+//
+// See http://goo.gl/BwcOT for more details.
+func (iam *IAM) ListGroupsCtx(ctx context.Context, pathPrefix, marker string, maxItems int) (*ListGroupsResp, error) {
+	params := map[string]string{"Action": "ListGroups"}
+	if pathPrefix != "" {
+		params["PathPrefix"] = pathPrefix
+	}
+	addPagination(params, marker, maxItems)
+	resp := new(ListGroupsResp)
+	if err := iam.queryCtx(ctx, params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EachGroup calls fn for every group with the given path prefix,
+// transparently following IAM's Marker pagination until the full result
+// set has been visited. Iteration stops at the first error, whether from
+// the IAM request or from fn itself.
+func (iam *IAM) EachGroup(pathPrefix string, fn func(Group) error) error {
+	return iam.EachGroupCtx(context.Background(), pathPrefix, fn)
+}
+
+// EachGroupCtx is EachGroup, bounding total iteration latency, including
+// retries across every page, by ctx.
+func (iam *IAM) EachGroupCtx(ctx context.Context, pathPrefix string, fn func(Group) error) error {
+	marker := ""
+	for {
+		resp, err := iam.ListGroupsCtx(ctx, pathPrefix, marker, 0)
+		if err != nil {
+			return err
+		}
+		for _, g := range resp.Groups {
+			if err := fn(g); err != nil {
+				return err
+			}
+		}
+		if !resp.IsTruncated {
+			return nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// EachGroupMember calls fn for every user belonging to the named group,
+// transparently following IAM's Marker pagination until the full
+// membership has been visited. Iteration stops at the first error,
+// whether from the IAM request or from fn itself.
+func (iam *IAM) EachGroupMember(name string, fn func(User) error) error {
+	return iam.EachGroupMemberCtx(context.Background(), name, fn)
+}
+
+// EachGroupMemberCtx is EachGroupMember, bounding total iteration
+// latency, including retries across every page, by ctx.
+func (iam *IAM) EachGroupMemberCtx(ctx context.Context, name string, fn func(User) error) error {
+	marker := ""
+	for {
+		resp, err := iam.GetGroupCtx(ctx, name, marker, 0)
+		if err != nil {
+			return err
+		}
+		for _, u := range resp.Users {
+			if err := fn(u); err != nil {
+				return err
+			}
+		}
+		if !resp.IsTruncated {
+			return nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// AddUserToGroup adds the user to the group.
+//
+// See http://goo.gl/Mx7Nr for more details.
+func (iam *IAM) AddUserToGroup(userName, groupName string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":    "AddUserToGroup",
+		"UserName":  userName,
+		"GroupName": groupName,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RemoveUserFromGroup removes the user from the group.
+//
+// See http://goo.gl/fTAYY for more details.
+func (iam *IAM) RemoveUserFromGroup(userName, groupName string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":    "RemoveUserFromGroup",
+		"UserName":  userName,
+		"GroupName": groupName,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Role encapsulates a role managed by IAM.
+//
+// See http://goo.gl/d0qfc for more details.
+type Role struct {
+	Arn                      string
+	Path                     string
+	Id                       string `xml:"RoleId"`
+	Name                     string `xml:"RoleName"`
+	AssumeRolePolicyDocument string
+	Tags                     []Tag `xml:"Tags>member"`
+	PermissionsBoundary      PermissionsBoundary
+}
+
+// Response to a CreateRole request.
+//
+// See http://goo.gl/9h9Ff for more details.
+type CreateRoleResp struct {
+	Role      Role   `xml:"CreateRoleResult>Role"`
+	RequestId string `xml:"ResponseMetadata>RequestId"`
+}
+
+// CreateRole creates a new role in IAM. assumeRolePolicyDocument is the
+// trust policy that grants permission to assume the role. tags may be
+// nil, and permissionsBoundary may be left empty to create the role with
+// no permissions boundary.
+//
+// See http://goo.gl/9h9Ff for more details.
+func (iam *IAM) CreateRole(name, path, assumeRolePolicyDocument string, tags []Tag, permissionsBoundary string) (*CreateRoleResp, error) {
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"Action":                   "CreateRole",
+		"RoleName":                 name,
+		"AssumeRolePolicyDocument": assumeRolePolicyDocument,
+	}
+	if path != "" {
+		params["Path"] = path
+	}
+	if permissionsBoundary != "" {
+		params["PermissionsBoundary"] = permissionsBoundary
+	}
+	addTagParams(params, tags)
+	resp := new(CreateRoleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteRole deletes a role from IAM. The role must not have any attached
+// policies or instance profiles.
+//
+// See http://goo.gl/0Ho8g for more details.
+func (iam *IAM) DeleteRole(name string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":   "DeleteRole",
+		"RoleName": name,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a GetRole request.
+//
+// See http://goo.gl/hyF0u for more details.
+type GetRoleResp struct {
+	Role      Role   `xml:"GetRoleResult>Role"`
+	RequestId string `xml:"ResponseMetadata>RequestId"`
+}
+
+// GetRole returns details about the role identified by name.
+//
+// See http://goo.gl/hyF0u for more details.
+func (iam *IAM) GetRole(name string) (*GetRoleResp, error) {
+	params := map[string]string{
+		"Action":   "GetRole",
+		"RoleName": name,
+	}
+	resp := new(GetRoleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a ListRoles request.
+//
+// See http://goo.gl/dKsMz for more details.
+type ListRolesResp struct {
+	Roles       []Role `xml:"ListRolesResult>Roles>member"`
+	IsTruncated bool   `xml:"ListRolesResult>IsTruncated"`
+	Marker      string `xml:"ListRolesResult>Marker"`
+	RequestId   string `xml:"ResponseMetadata>RequestId"`
+}
+
+// ListRoles lists the roles that have the given path prefix. If pathPrefix
+// is empty, it defaults to "/", which lists all roles. marker resumes a
+// previous truncated listing, and maxItems bounds the page size; a zero
+// maxItems lets IAM pick its default.
+//
+// See http://goo.gl/dKsMz for more details.
+func (iam *IAM) ListRoles(pathPrefix, marker string, maxItems int) (*ListRolesResp, error) {
+	return iam.ListRolesCtx(context.Background(), pathPrefix, marker, maxItems)
+}
+
+// ListRolesCtx is ListRoles, bounding total request latency, including
+// retries, by ctx.
+//
+// See http://goo.gl/dKsMz for more details.
+func (iam *IAM) ListRolesCtx(ctx context.Context, pathPrefix, marker string, maxItems int) (*ListRolesResp, error) {
+	params := map[string]string{"Action": "ListRoles"}
+	if pathPrefix != "" {
+		params["PathPrefix"] = pathPrefix
+	}
+	addPagination(params, marker, maxItems)
+	resp := new(ListRolesResp)
+	if err := iam.queryCtx(ctx, params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EachRole calls fn for every role with the given path prefix,
+// transparently following IAM's Marker pagination until the full result
+// set has been visited. Iteration stops at the first error, whether from
+// the IAM request or from fn itself.
+func (iam *IAM) EachRole(pathPrefix string, fn func(Role) error) error {
+	return iam.EachRoleCtx(context.Background(), pathPrefix, fn)
+}
+
+// EachRoleCtx is EachRole, bounding total iteration latency, including
+// retries across every page, by ctx.
+func (iam *IAM) EachRoleCtx(ctx context.Context, pathPrefix string, fn func(Role) error) error {
+	marker := ""
+	for {
+		resp, err := iam.ListRolesCtx(ctx, pathPrefix, marker, 0)
+		if err != nil {
+			return err
+		}
+		for _, r := range resp.Roles {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+		if !resp.IsTruncated {
+			return nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// PutUserPolicy adds or updates an inline policy document embedded in the
+// named user.
+//
+// See http://goo.gl/CX8Qr for more details.
+func (iam *IAM) PutUserPolicy(userName, policyName, policyDocument string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":         "PutUserPolicy",
+		"UserName":       userName,
+		"PolicyName":     policyName,
+		"PolicyDocument": policyDocument,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a GetUserPolicy request.
+//
+// See http://goo.gl/RMYX9 for more details.
+type GetUserPolicyResp struct {
+	UserName       string `xml:"GetUserPolicyResult>UserName"`
+	PolicyName     string `xml:"GetUserPolicyResult>PolicyName"`
+	PolicyDocument string `xml:"GetUserPolicyResult>PolicyDocument"`
+	RequestId      string `xml:"ResponseMetadata>RequestId"`
+}
+
+// GetUserPolicy returns the inline policy document embedded in the named
+// user.
+//
+// See http://goo.gl/RMYX9 for more details.
+func (iam *IAM) GetUserPolicy(userName, policyName string) (*GetUserPolicyResp, error) {
+	params := map[string]string{
+		"Action":     "GetUserPolicy",
+		"UserName":   userName,
+		"PolicyName": policyName,
+	}
+	resp := new(GetUserPolicyResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteUserPolicy deletes an inline policy document embedded in the named
+// user.
+//
+// See http://goo.gl/wO7qy for more details.
+func (iam *IAM) DeleteUserPolicy(userName, policyName string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":     "DeleteUserPolicy",
+		"UserName":   userName,
+		"PolicyName": policyName,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a ListUserPolicies request.
+//
+// See http://goo.gl/18oc2 for more details.
+type ListUserPoliciesResp struct {
+	PolicyNames []string `xml:"ListUserPoliciesResult>PolicyNames>member"`
+	IsTruncated bool     `xml:"ListUserPoliciesResult>IsTruncated"`
+	Marker      string   `xml:"ListUserPoliciesResult>Marker"`
+	RequestId   string   `xml:"ResponseMetadata>RequestId"`
+}
+
+// ListUserPolicies lists the names of the inline policies embedded in the
+// named user. marker resumes a previous truncated listing, and maxItems
+// bounds the page size; a zero maxItems lets IAM pick its default.
+//
+// See http://goo.gl/18oc2 for more details.
+func (iam *IAM) ListUserPolicies(userName, marker string, maxItems int) (*ListUserPoliciesResp, error) {
+	return iam.ListUserPoliciesCtx(context.Background(), userName, marker, maxItems)
+}
+
+// ListUserPoliciesCtx is ListUserPolicies, bounding total request
+// latency, including retries, by ctx.
+//
+// See http://goo.gl/18oc2 for more details.
+func (iam *IAM) ListUserPoliciesCtx(ctx context.Context, userName, marker string, maxItems int) (*ListUserPoliciesResp, error) {
+	params := map[string]string{
+		"Action":   "ListUserPolicies",
+		"UserName": userName,
+	}
+	addPagination(params, marker, maxItems)
+	resp := new(ListUserPoliciesResp)
+	if err := iam.queryCtx(ctx, params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EachUserPolicy calls fn for the name of every inline policy embedded in
+// the named user, transparently following IAM's Marker pagination until
+// the full result set has been visited. Iteration stops at the first
+// error, whether from the IAM request or from fn itself.
+func (iam *IAM) EachUserPolicy(userName string, fn func(string) error) error {
+	return iam.EachUserPolicyCtx(context.Background(), userName, fn)
+}
+
+// EachUserPolicyCtx is EachUserPolicy, bounding total iteration latency,
+// including retries across every page, by ctx.
+func (iam *IAM) EachUserPolicyCtx(ctx context.Context, userName string, fn func(string) error) error {
+	marker := ""
+	for {
+		resp, err := iam.ListUserPoliciesCtx(ctx, userName, marker, 0)
+		if err != nil {
+			return err
+		}
+		for _, name := range resp.PolicyNames {
+			if err := fn(name); err != nil {
+				return err
+			}
+		}
+		if !resp.IsTruncated {
+			return nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// PutGroupPolicy adds or updates an inline policy document embedded in the
+// named group.
+//
+// See http://goo.gl/2y9k1 for more details.
+func (iam *IAM) PutGroupPolicy(groupName, policyName, policyDocument string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":         "PutGroupPolicy",
+		"GroupName":      groupName,
+		"PolicyName":     policyName,
+		"PolicyDocument": policyDocument,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a GetGroupPolicy request.
+//
+// See http://goo.gl/XYQ1L for more details.
+type GetGroupPolicyResp struct {
+	GroupName      string `xml:"GetGroupPolicyResult>GroupName"`
+	PolicyName     string `xml:"GetGroupPolicyResult>PolicyName"`
+	PolicyDocument string `xml:"GetGroupPolicyResult>PolicyDocument"`
+	RequestId      string `xml:"ResponseMetadata>RequestId"`
+}
+
+// GetGroupPolicy returns the inline policy document embedded in the named
+// group.
+//
+// See http://goo.gl/XYQ1L for more details.
+func (iam *IAM) GetGroupPolicy(groupName, policyName string) (*GetGroupPolicyResp, error) {
+	params := map[string]string{
+		"Action":     "GetGroupPolicy",
+		"GroupName":  groupName,
+		"PolicyName": policyName,
+	}
+	resp := new(GetGroupPolicyResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteGroupPolicy deletes an inline policy document embedded in the named
+// group.
+//
+// See http://goo.gl/1fjdt for more details.
+func (iam *IAM) DeleteGroupPolicy(groupName, policyName string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":     "DeleteGroupPolicy",
+		"GroupName":  groupName,
+		"PolicyName": policyName,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a ListGroupPolicies request.
+//
+// See http://goo.gl/1nA5l for more details.
+type ListGroupPoliciesResp struct {
+	PolicyNames []string `xml:"ListGroupPoliciesResult>PolicyNames>member"`
+	IsTruncated bool     `xml:"ListGroupPoliciesResult>IsTruncated"`
+	Marker      string   `xml:"ListGroupPoliciesResult>Marker"`
+	RequestId   string   `xml:"ResponseMetadata>RequestId"`
+}
+
+// ListGroupPolicies lists the names of the inline policies embedded in the
+// named group. marker resumes a previous truncated listing, and maxItems
+// bounds the page size; a zero maxItems lets IAM pick its default.
+//
+// See http://goo.gl/1nA5l for more details.
+func (iam *IAM) ListGroupPolicies(groupName, marker string, maxItems int) (*ListGroupPoliciesResp, error) {
+	return iam.ListGroupPoliciesCtx(context.Background(), groupName, marker, maxItems)
+}
+
+// ListGroupPoliciesCtx is ListGroupPolicies, bounding total request
+// latency, including retries, by ctx.
+//
+// See http://goo.gl/1nA5l for more details.
+func (iam *IAM) ListGroupPoliciesCtx(ctx context.Context, groupName, marker string, maxItems int) (*ListGroupPoliciesResp, error) {
+	params := map[string]string{
+		"Action":    "ListGroupPolicies",
+		"GroupName": groupName,
+	}
+	addPagination(params, marker, maxItems)
+	resp := new(ListGroupPoliciesResp)
+	if err := iam.queryCtx(ctx, params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EachGroupPolicy calls fn for the name of every inline policy embedded in
+// the named group, transparently following IAM's Marker pagination until
+// the full result set has been visited. Iteration stops at the first
+// error, whether from the IAM request or from fn itself.
+func (iam *IAM) EachGroupPolicy(groupName string, fn func(string) error) error {
+	return iam.EachGroupPolicyCtx(context.Background(), groupName, fn)
+}
+
+// EachGroupPolicyCtx is EachGroupPolicy, bounding total iteration
+// latency, including retries across every page, by ctx.
+func (iam *IAM) EachGroupPolicyCtx(ctx context.Context, groupName string, fn func(string) error) error {
+	marker := ""
+	for {
+		resp, err := iam.ListGroupPoliciesCtx(ctx, groupName, marker, 0)
+		if err != nil {
+			return err
+		}
+		for _, name := range resp.PolicyNames {
+			if err := fn(name); err != nil {
+				return err
+			}
+		}
+		if !resp.IsTruncated {
+			return nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// PutRolePolicy adds or updates an inline policy document embedded in the
+// named role.
+//
+// See http://goo.gl/7SZti for more details.
+func (iam *IAM) PutRolePolicy(roleName, policyName, policyDocument string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":         "PutRolePolicy",
+		"RoleName":       roleName,
+		"PolicyName":     policyName,
+		"PolicyDocument": policyDocument,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a GetRolePolicy request.
+//
+// See http://goo.gl/k4xCG for more details.
+type GetRolePolicyResp struct {
+	RoleName       string `xml:"GetRolePolicyResult>RoleName"`
+	PolicyName     string `xml:"GetRolePolicyResult>PolicyName"`
+	PolicyDocument string `xml:"GetRolePolicyResult>PolicyDocument"`
+	RequestId      string `xml:"ResponseMetadata>RequestId"`
+}
+
+// GetRolePolicy returns the inline policy document embedded in the named
+// role.
+//
+// See http://goo.gl/k4xCG for more details.
+func (iam *IAM) GetRolePolicy(roleName, policyName string) (*GetRolePolicyResp, error) {
+	params := map[string]string{
+		"Action":     "GetRolePolicy",
+		"RoleName":   roleName,
+		"PolicyName": policyName,
+	}
+	resp := new(GetRolePolicyResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteRolePolicy deletes an inline policy document embedded in the named
+// role.
+//
+// See http://goo.gl/9TExl for more details.
+func (iam *IAM) DeleteRolePolicy(roleName, policyName string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":     "DeleteRolePolicy",
+		"RoleName":   roleName,
+		"PolicyName": policyName,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a ListRolePolicies request.
+//
+// See http://goo.gl/8Wukb for more details.
+type ListRolePoliciesResp struct {
+	PolicyNames []string `xml:"ListRolePoliciesResult>PolicyNames>member"`
+	IsTruncated bool     `xml:"ListRolePoliciesResult>IsTruncated"`
+	Marker      string   `xml:"ListRolePoliciesResult>Marker"`
+	RequestId   string   `xml:"ResponseMetadata>RequestId"`
+}
+
+// ListRolePolicies lists the names of the inline policies embedded in the
+// named role. marker resumes a previous truncated listing, and maxItems
+// bounds the page size; a zero maxItems lets IAM pick its default.
+//
+// See http://goo.gl/8Wukb for more details.
+func (iam *IAM) ListRolePolicies(roleName, marker string, maxItems int) (*ListRolePoliciesResp, error) {
+	return iam.ListRolePoliciesCtx(context.Background(), roleName, marker, maxItems)
+}
+
+// ListRolePoliciesCtx is ListRolePolicies, bounding total request
+// latency, including retries, by ctx.
+//
+// See http://goo.gl/8Wukb for more details.
+func (iam *IAM) ListRolePoliciesCtx(ctx context.Context, roleName, marker string, maxItems int) (*ListRolePoliciesResp, error) {
+	params := map[string]string{
+		"Action":   "ListRolePolicies",
+		"RoleName": roleName,
+	}
+	addPagination(params, marker, maxItems)
+	resp := new(ListRolePoliciesResp)
+	if err := iam.queryCtx(ctx, params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EachRolePolicy calls fn for the name of every inline policy embedded in
+// the named role, transparently following IAM's Marker pagination until
+// the full result set has been visited. Iteration stops at the first
+// error, whether from the IAM request or from fn itself.
+func (iam *IAM) EachRolePolicy(roleName string, fn func(string) error) error {
+	return iam.EachRolePolicyCtx(context.Background(), roleName, fn)
+}
+
+// EachRolePolicyCtx is EachRolePolicy, bounding total iteration latency,
+// including retries across every page, by ctx.
+func (iam *IAM) EachRolePolicyCtx(ctx context.Context, roleName string, fn func(string) error) error {
+	marker := ""
+	for {
+		resp, err := iam.ListRolePoliciesCtx(ctx, roleName, marker, 0)
+		if err != nil {
+			return err
+		}
+		for _, name := range resp.PolicyNames {
+			if err := fn(name); err != nil {
+				return err
+			}
+		}
+		if !resp.IsTruncated {
+			return nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// Policy encapsulates a managed policy in IAM.
+//
+// See http://goo.gl/oA4yH for more details.
+type Policy struct {
+	Arn              string
+	Path             string
+	Id               string `xml:"PolicyId"`
+	Name             string `xml:"PolicyName"`
+	DefaultVersionId string
+}
+
+// Response to a CreatePolicy request.
+//
+// See http://goo.gl/oA4yH for more details.
+type CreatePolicyResp struct {
+	Policy    Policy `xml:"CreatePolicyResult>Policy"`
+	RequestId string `xml:"ResponseMetadata>RequestId"`
+}
+
+// CreatePolicy creates a new managed policy in IAM. description may be
+// left empty.
+//
+// See http://goo.gl/oA4yH for more details.
+func (iam *IAM) CreatePolicy(name, path, policyDocument, description string) (*CreatePolicyResp, error) {
+	params := map[string]string{
+		"Action":         "CreatePolicy",
+		"PolicyName":     name,
+		"PolicyDocument": policyDocument,
+	}
+	if path != "" {
+		params["Path"] = path
+	}
+	if description != "" {
+		params["Description"] = description
+	}
+	resp := new(CreatePolicyResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeletePolicy deletes the managed policy identified by arn. The policy
+// must not be attached to any user, group, or role.
+//
+// See http://goo.gl/tDDXW for more details.
+func (iam *IAM) DeletePolicy(arn string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":    "DeletePolicy",
+		"PolicyArn": arn,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AttachUserPolicy attaches the managed policy identified by policyArn to
+// the named user.
+//
+// See http://goo.gl/Pwcsm for more details.
+func (iam *IAM) AttachUserPolicy(userName, policyArn string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":    "AttachUserPolicy",
+		"UserName":  userName,
+		"PolicyArn": policyArn,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DetachUserPolicy detaches the managed policy identified by policyArn
+// from the named user.
+//
+// See http://goo.gl/M1dKh for more details.
+func (iam *IAM) DetachUserPolicy(userName, policyArn string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":    "DetachUserPolicy",
+		"UserName":  userName,
+		"PolicyArn": policyArn,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AttachGroupPolicy attaches the managed policy identified by policyArn to
+// the named group.
+//
+// See http://goo.gl/s1ZQj for more details.
+func (iam *IAM) AttachGroupPolicy(groupName, policyArn string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":    "AttachGroupPolicy",
+		"GroupName": groupName,
+		"PolicyArn": policyArn,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DetachGroupPolicy detaches the managed policy identified by policyArn
+// from the named group.
+//
+// See http://goo.gl/9uPUq for more details.
+func (iam *IAM) DetachGroupPolicy(groupName, policyArn string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":    "DetachGroupPolicy",
+		"GroupName": groupName,
+		"PolicyArn": policyArn,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AttachRolePolicy attaches the managed policy identified by policyArn to
+// the named role.
+//
+// See http://goo.gl/cz9A1 for more details.
+func (iam *IAM) AttachRolePolicy(roleName, policyArn string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":    "AttachRolePolicy",
+		"RoleName":  roleName,
+		"PolicyArn": policyArn,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DetachRolePolicy detaches the managed policy identified by policyArn
+// from the named role.
+//
+// See http://goo.gl/k0bQl for more details.
+func (iam *IAM) DetachRolePolicy(roleName, policyArn string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":    "DetachRolePolicy",
+		"RoleName":  roleName,
+		"PolicyArn": policyArn,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// InstanceProfile encapsulates an instance profile managed by IAM.
+//
+// See http://goo.gl/KFxOH for more details.
+type InstanceProfile struct {
+	Arn   string
+	Path  string
+	Id    string `xml:"InstanceProfileId"`
+	Name  string `xml:"InstanceProfileName"`
+	Roles []Role `xml:"Roles>member"`
+}
+
+// Response to a CreateInstanceProfile request.
+//
+// See http://goo.gl/9U0KO for more details.
+type CreateInstanceProfileResp struct {
+	InstanceProfile InstanceProfile `xml:"CreateInstanceProfileResult>InstanceProfile"`
+	RequestId       string          `xml:"ResponseMetadata>RequestId"`
+}
+
+// CreateInstanceProfile creates a new instance profile in IAM.
+//
+// See http://goo.gl/9U0KO for more details.
+func (iam *IAM) CreateInstanceProfile(name, path string) (*CreateInstanceProfileResp, error) {
+	params := map[string]string{
+		"Action":              "CreateInstanceProfile",
+		"InstanceProfileName": name,
+	}
+	if path != "" {
+		params["Path"] = path
+	}
+	resp := new(CreateInstanceProfileResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteInstanceProfile deletes an instance profile from IAM. The instance
+// profile must not have any roles associated with it.
+//
+// See http://goo.gl/WeFBM for more details.
+func (iam *IAM) DeleteInstanceProfile(name string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":              "DeleteInstanceProfile",
+		"InstanceProfileName": name,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AddRoleToInstanceProfile adds the named role to the named instance
+// profile.
+//
+// See http://goo.gl/wJdUn for more details.
+func (iam *IAM) AddRoleToInstanceProfile(instanceProfileName, roleName string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":              "AddRoleToInstanceProfile",
+		"InstanceProfileName": instanceProfileName,
+		"RoleName":            roleName,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RemoveRoleFromInstanceProfile removes the named role from the named
+// instance profile.
+//
+// See http://goo.gl/pkeMT for more details.
+func (iam *IAM) RemoveRoleFromInstanceProfile(instanceProfileName, roleName string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":              "RemoveRoleFromInstanceProfile",
+		"InstanceProfileName": instanceProfileName,
+		"RoleName":            roleName,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a CreateAccessKey request.
+//
+// See http://goo.gl/L46Py for more details.
+type CreateAccessKeyResp struct {
+	AccessKey AccessKey `xml:"CreateAccessKeyResult>AccessKey"`
+	RequestId string    `xml:"ResponseMetadata>RequestId"`
+}
+
+// AccessKey encapsulates an access key generated for a user.
+//
+// See http://goo.gl/LHgZR for more details.
+type AccessKey struct {
+	User   string `xml:"UserName"`
+	Id     string `xml:"AccessKeyId"`
+	Secret string `xml:"SecretAccessKey"`
+	Status string
+}
+
+// CreateAccessKey creates a new access key in IAM.
+//
+// See http://goo.gl/L46Py for more details.
+func (iam *IAM) CreateAccessKey(userName string) (*CreateAccessKeyResp, error) {
+	params := map[string]string{
+		"Action":   "CreateAccessKey",
+		"UserName": userName,
+	}
+	resp := new(CreateAccessKeyResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteAccessKey deletes the access key identified by accessKeyId,
+// belonging to the named user.
+//
+// See http://goo.gl/h0Upu for more details.
+func (iam *IAM) DeleteAccessKey(accessKeyId, userName string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":      "DeleteAccessKey",
+		"AccessKeyId": accessKeyId,
+	}
+	if userName != "" {
+		params["UserName"] = userName
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a ListAccessKeys request.
+//
+// See http://goo.gl/dbHjD for more details.
+type ListAccessKeysResp struct {
+	AccessKeys  []AccessKey `xml:"ListAccessKeysResult>AccessKeyMetadata>member"`
+	IsTruncated bool        `xml:"ListAccessKeysResult>IsTruncated"`
+	Marker      string      `xml:"ListAccessKeysResult>Marker"`
+	RequestId   string      `xml:"ResponseMetadata>RequestId"`
+}
+
+// ListAccessKeys lists the access keys belonging to the named user. If
+// userName is empty, the access keys of the user making the request are
+// listed. marker resumes a previous truncated listing, and maxItems
+// bounds the page size; a zero maxItems lets IAM pick its default.
+//
+// See http://goo.gl/dbHjD for more details.
+func (iam *IAM) ListAccessKeys(userName, marker string, maxItems int) (*ListAccessKeysResp, error) {
+	return iam.ListAccessKeysCtx(context.Background(), userName, marker, maxItems)
+}
+
+// ListAccessKeysCtx is ListAccessKeys, bounding total request latency,
+// including retries, by ctx.
+//
+// See http://goo.gl/dbHjD for more details.
+func (iam *IAM) ListAccessKeysCtx(ctx context.Context, userName, marker string, maxItems int) (*ListAccessKeysResp, error) {
+	params := map[string]string{"Action": "ListAccessKeys"}
+	if userName != "" {
+		params["UserName"] = userName
+	}
+	addPagination(params, marker, maxItems)
+	resp := new(ListAccessKeysResp)
+	if err := iam.queryCtx(ctx, params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EachAccessKey calls fn for every access key belonging to the named
+// user, transparently following IAM's Marker pagination until the full
+// result set has been visited. Iteration stops at the first error,
+// whether from the IAM request or from fn itself.
+func (iam *IAM) EachAccessKey(userName string, fn func(AccessKey) error) error {
+	return iam.EachAccessKeyCtx(context.Background(), userName, fn)
+}
+
+// EachAccessKeyCtx is EachAccessKey, bounding total iteration latency,
+// including retries across every page, by ctx.
+func (iam *IAM) EachAccessKeyCtx(ctx context.Context, userName string, fn func(AccessKey) error) error {
+	marker := ""
+	for {
+		resp, err := iam.ListAccessKeysCtx(ctx, userName, marker, 0)
+		if err != nil {
+			return err
+		}
+		for _, k := range resp.AccessKeys {
+			if err := fn(k); err != nil {
+				return err
+			}
+		}
+		if !resp.IsTruncated {
+			return nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// UpdateAccessKey activates or deactivates the access key identified by
+// accessKeyId, belonging to the named user. status must be either
+// "Active" or "Inactive".
+//
+// See http://goo.gl/Kf0vG for more details.
+func (iam *IAM) UpdateAccessKey(accessKeyId, userName, status string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":      "UpdateAccessKey",
+		"AccessKeyId": accessKeyId,
+		"Status":      status,
+	}
+	if userName != "" {
+		params["UserName"] = userName
+	}
+	resp := new(SimpleResp)
 	if err := iam.query(params, resp); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,67 @@
+package iam
+
+// PutUserPermissionsBoundary sets or replaces the permissions boundary
+// for the named user to the managed policy identified by policyArn.
+//
+// See http://goo.gl/f3Nq8 for more details.
+func (iam *IAM) PutUserPermissionsBoundary(userName, policyArn string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":              "PutUserPermissionsBoundary",
+		"UserName":            userName,
+		"PermissionsBoundary": policyArn,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteUserPermissionsBoundary removes the permissions boundary from the
+// named user.
+//
+// See http://goo.gl/m9Xrc for more details.
+func (iam *IAM) DeleteUserPermissionsBoundary(userName string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":   "DeleteUserPermissionsBoundary",
+		"UserName": userName,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PutRolePermissionsBoundary sets or replaces the permissions boundary
+// for the named role to the managed policy identified by policyArn.
+//
+// See http://goo.gl/t2Lwy for more details.
+func (iam *IAM) PutRolePermissionsBoundary(roleName, policyArn string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":              "PutRolePermissionsBoundary",
+		"RoleName":            roleName,
+		"PermissionsBoundary": policyArn,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteRolePermissionsBoundary removes the permissions boundary from the
+// named role.
+//
+// See http://goo.gl/v7Hbn for more details.
+func (iam *IAM) DeleteRolePermissionsBoundary(roleName string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":   "DeleteRolePermissionsBoundary",
+		"RoleName": roleName,
+	}
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
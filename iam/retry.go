@@ -0,0 +1,93 @@
+package iam
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// RetryConfig controls how (*IAM).queryCtx retries a request that fails
+// with a throttling error or a transient 5xx response. HTTP 5xx
+// responses are always retryable; RetryableCodes lists additional AWS
+// error codes that should be retried too.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts, including the
+	// first. Zero uses DefaultRetryConfig.MaxAttempts; any other value
+	// of 1 or less disables retries.
+	MaxAttempts int
+
+	// BaseDelay is the initial backoff delay, doubled after each
+	// attempt.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff delay.
+	MaxDelay time.Duration
+
+	// RetryableCodes lists the AWS error codes that are safe to retry,
+	// in addition to HTTP 5xx responses.
+	RetryableCodes []string
+}
+
+// DefaultRetryConfig is used for any zero-valued field of an IAM value's
+// RetryConfig.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts:    5,
+	BaseDelay:      100 * time.Millisecond,
+	MaxDelay:       20 * time.Second,
+	RetryableCodes: []string{"Throttling", "ThrottlingException"},
+}
+
+// retryConfig returns iam.RetryConfig with any zero-valued field filled
+// in from DefaultRetryConfig.
+func (iam *IAM) retryConfig() RetryConfig {
+	rc := iam.RetryConfig
+	if rc.MaxAttempts == 0 {
+		rc.MaxAttempts = DefaultRetryConfig.MaxAttempts
+	}
+	if rc.BaseDelay == 0 {
+		rc.BaseDelay = DefaultRetryConfig.BaseDelay
+	}
+	if rc.MaxDelay == 0 {
+		rc.MaxDelay = DefaultRetryConfig.MaxDelay
+	}
+	if rc.RetryableCodes == nil {
+		rc.RetryableCodes = DefaultRetryConfig.RetryableCodes
+	}
+	return rc
+}
+
+// isRetryable reports whether err is a transient failure worth retrying:
+// an HTTP 5xx response, or one of rc.RetryableCodes.
+func (rc RetryConfig) isRetryable(err error) bool {
+	ierr, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+	if ierr.StatusCode >= 500 {
+		return true
+	}
+	for _, code := range rc.RetryableCodes {
+		if ierr.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// waitBackoff sleeps for a full-jitter exponential backoff delay before
+// the next attempt, returning early with ctx.Err() if ctx is done first.
+func waitBackoff(ctx context.Context, rc RetryConfig, attempt int) error {
+	delay := rc.BaseDelay << uint(attempt)
+	if delay <= 0 || delay > rc.MaxDelay {
+		delay = rc.MaxDelay
+	}
+
+	t := time.NewTimer(time.Duration(rand.Int63n(int64(delay))))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
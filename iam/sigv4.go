@@ -0,0 +1,124 @@
+package iam
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"launchpad.net/goamz/aws"
+	"sort"
+	"strings"
+	"time"
+)
+
+// iamServiceName is the service name used in the SigV4 credential scope
+// for all IAM requests.
+const iamServiceName = "iam"
+
+// iso8601BasicFormat is the x-amz-date layout required by Signature
+// Version 4.
+const iso8601BasicFormat = "20060102T150405Z"
+
+// iso8601BasicFormatShort is the date layout of the SigV4 credential
+// scope.
+const iso8601BasicFormatShort = "20060102"
+
+// signV4 signs an IAM request using Signature Version 4 and returns the
+// value of the Authorization header to attach to it. IAM has no request
+// body, so the payload hash is always that of the empty string.
+//
+// See http://goo.gl/4sQkP for more details.
+func signV4(auth aws.Auth, region, method, uri string, params map[string]string, host string, t time.Time) string {
+	amzDate := t.Format(iso8601BasicFormat)
+	dateStamp := t.Format(iso8601BasicFormatShort)
+
+	canonicalHeaders := "host:" + host + "\nx-amz-date:" + amzDate + "\n"
+	signedHeaders := "host;x-amz-date"
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		uri,
+		canonicalQueryString(params),
+		canonicalHeaders,
+		signedHeaders,
+		sha256Hex(nil),
+	}, "\n")
+
+	scope := dateStamp + "/" + region + "/" + iamServiceName + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := v4SigningKey(auth.SecretKey, dateStamp, region)
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	return "AWS4-HMAC-SHA256 Credential=" + auth.AccessKey + "/" + scope +
+		", SignedHeaders=" + signedHeaders +
+		", Signature=" + signature
+}
+
+// canonicalQueryString builds the sorted, percent-encoded query string
+// required by the SigV4 canonical request. The same string is used
+// verbatim as the request's wire query string, since SigV4 requires the
+// signer and the server to canonicalize the query identically.
+func canonicalQueryString(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = awsURIEncode(k, true) + "=" + awsURIEncode(params[k], true)
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsURIEncode percent-encodes s the way SigV4 requires: unreserved
+// characters (A-Za-z0-9-_.~) pass through unchanged, and every other
+// byte, including space, becomes a %XX escape with uppercase hex digits.
+// This differs from url.QueryEscape, which encodes a space as "+" rather
+// than "%20" and so produces a query string AWS's own canonicalization
+// won't reproduce. encodeSlash controls whether '/' is escaped too;
+// query string keys and values must escape it, while a canonical URI
+// path leaves single slashes alone as path separators.
+func awsURIEncode(s string, encodeSlash bool) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			buf.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			buf.WriteByte(c)
+		default:
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
+
+// v4SigningKey derives the SigV4 signing key by chaining HMAC-SHA256 over
+// the date, region, and service name.
+func v4SigningKey(secretKey, dateStamp, region string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secretKey), dateStamp)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, iamServiceName)
+	return hmacSHA256(kService, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
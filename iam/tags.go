@@ -0,0 +1,265 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Limits on user/role tags documented by IAM.
+//
+// See http://goo.gl/aC9Lk for more details.
+const (
+	maxTagsPerResource = 50
+	maxTagKeyLength    = 128
+	maxTagValueLength  = 256
+)
+
+// Tag is a key/value pair attached to an IAM user or role.
+//
+// See http://goo.gl/wZ2Qf for more details.
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// validateTags checks tag keys and values against the limits IAM
+// documents for user and role tags, returning a typed *Error describing
+// the first violation found.
+func validateTags(tags []Tag) error {
+	if len(tags) > maxTagsPerResource {
+		return &Error{Message: fmt.Sprintf("too many tags: got %d, max is %d", len(tags), maxTagsPerResource)}
+	}
+	for _, t := range tags {
+		if n := utf8.RuneCountInString(t.Key); n == 0 || n > maxTagKeyLength {
+			return &Error{Message: fmt.Sprintf("invalid tag key %q: must be 1-%d characters", t.Key, maxTagKeyLength)}
+		}
+		if utf8.RuneCountInString(t.Value) > maxTagValueLength {
+			return &Error{Message: fmt.Sprintf("invalid tag value for key %q: must be at most %d characters", t.Key, maxTagValueLength)}
+		}
+	}
+	return nil
+}
+
+// addTagParams serializes tags as the Tags.member.N.Key/Tags.member.N.Value
+// query parameters IAM expects.
+func addTagParams(params map[string]string, tags []Tag) {
+	for i, t := range tags {
+		n := strconv.Itoa(i + 1)
+		params["Tags.member."+n+".Key"] = t.Key
+		params["Tags.member."+n+".Value"] = t.Value
+	}
+}
+
+// addTagKeyParams serializes tag keys as the TagKeys.member.N parameters
+// expected by the Untag* actions.
+func addTagKeyParams(params map[string]string, tagKeys []string) {
+	for i, k := range tagKeys {
+		params["TagKeys.member."+strconv.Itoa(i+1)] = k
+	}
+}
+
+// TagUser attaches the given tags to the named user, up to the 50 tags
+// per resource that IAM allows. Tagging with a key that already exists
+// overwrites its value.
+//
+// See http://goo.gl/xM5Tr for more details.
+func (iam *IAM) TagUser(userName string, tags []Tag) (*SimpleResp, error) {
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"Action":   "TagUser",
+		"UserName": userName,
+	}
+	addTagParams(params, tags)
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UntagUser removes the given tag keys from the named user.
+//
+// See http://goo.gl/kP7Nc for more details.
+func (iam *IAM) UntagUser(userName string, tagKeys []string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":   "UntagUser",
+		"UserName": userName,
+	}
+	addTagKeyParams(params, tagKeys)
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a ListUserTags request.
+//
+// See http://goo.gl/hY3Wd for more details.
+type ListUserTagsResp struct {
+	Tags        []Tag  `xml:"ListUserTagsResult>Tags>member"`
+	IsTruncated bool   `xml:"ListUserTagsResult>IsTruncated"`
+	Marker      string `xml:"ListUserTagsResult>Marker"`
+	RequestId   string `xml:"ResponseMetadata>RequestId"`
+}
+
+// ListUserTags lists the tags attached to the named user. marker resumes
+// a previous truncated listing, and maxItems bounds the page size; a
+// zero maxItems lets IAM pick its default.
+//
+// See http://goo.gl/bL6Vs for more details.
+func (iam *IAM) ListUserTags(userName, marker string, maxItems int) (*ListUserTagsResp, error) {
+	return iam.ListUserTagsCtx(context.Background(), userName, marker, maxItems)
+}
+
+// ListUserTagsCtx is ListUserTags, bounding total request latency,
+// including retries, by ctx.
+//
+// See http://goo.gl/bL6Vs for more details.
+func (iam *IAM) ListUserTagsCtx(ctx context.Context, userName, marker string, maxItems int) (*ListUserTagsResp, error) {
+	params := map[string]string{
+		"Action":   "ListUserTags",
+		"UserName": userName,
+	}
+	addPagination(params, marker, maxItems)
+	resp := new(ListUserTagsResp)
+	if err := iam.queryCtx(ctx, params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EachUserTag calls fn for every tag attached to the named user,
+// transparently following IAM's Marker pagination until the full result
+// set has been visited. Iteration stops at the first error, whether from
+// the IAM request or from fn itself.
+func (iam *IAM) EachUserTag(userName string, fn func(Tag) error) error {
+	return iam.EachUserTagCtx(context.Background(), userName, fn)
+}
+
+// EachUserTagCtx is EachUserTag, bounding total iteration latency,
+// including retries across every page, by ctx.
+func (iam *IAM) EachUserTagCtx(ctx context.Context, userName string, fn func(Tag) error) error {
+	marker := ""
+	for {
+		resp, err := iam.ListUserTagsCtx(ctx, userName, marker, 0)
+		if err != nil {
+			return err
+		}
+		for _, t := range resp.Tags {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+		if !resp.IsTruncated {
+			return nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// TagRole attaches the given tags to the named role, up to the 50 tags
+// per resource that IAM allows. Tagging with a key that already exists
+// overwrites its value.
+//
+// See http://goo.gl/eJ1Ug for more details.
+func (iam *IAM) TagRole(roleName string, tags []Tag) (*SimpleResp, error) {
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"Action":   "TagRole",
+		"RoleName": roleName,
+	}
+	addTagParams(params, tags)
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UntagRole removes the given tag keys from the named role.
+//
+// See http://goo.gl/qD8Yn for more details.
+func (iam *IAM) UntagRole(roleName string, tagKeys []string) (*SimpleResp, error) {
+	params := map[string]string{
+		"Action":   "UntagRole",
+		"RoleName": roleName,
+	}
+	addTagKeyParams(params, tagKeys)
+	resp := new(SimpleResp)
+	if err := iam.query(params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Response to a ListRoleTags request.
+//
+// See http://goo.gl/nQ8mX for more details.
+type ListRoleTagsResp struct {
+	Tags        []Tag  `xml:"ListRoleTagsResult>Tags>member"`
+	IsTruncated bool   `xml:"ListRoleTagsResult>IsTruncated"`
+	Marker      string `xml:"ListRoleTagsResult>Marker"`
+	RequestId   string `xml:"ResponseMetadata>RequestId"`
+}
+
+// ListRoleTags lists the tags attached to the named role. marker resumes
+// a previous truncated listing, and maxItems bounds the page size; a
+// zero maxItems lets IAM pick its default.
+//
+// See http://goo.gl/rT4sP for more details.
+func (iam *IAM) ListRoleTags(roleName, marker string, maxItems int) (*ListRoleTagsResp, error) {
+	return iam.ListRoleTagsCtx(context.Background(), roleName, marker, maxItems)
+}
+
+// ListRoleTagsCtx is ListRoleTags, bounding total request latency,
+// including retries, by ctx.
+//
+// See http://goo.gl/rT4sP for more details.
+func (iam *IAM) ListRoleTagsCtx(ctx context.Context, roleName, marker string, maxItems int) (*ListRoleTagsResp, error) {
+	params := map[string]string{
+		"Action":   "ListRoleTags",
+		"RoleName": roleName,
+	}
+	addPagination(params, marker, maxItems)
+	resp := new(ListRoleTagsResp)
+	if err := iam.queryCtx(ctx, params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EachRoleTag calls fn for every tag attached to the named role,
+// transparently following IAM's Marker pagination until the full result
+// set has been visited. Iteration stops at the first error, whether from
+// the IAM request or from fn itself.
+func (iam *IAM) EachRoleTag(roleName string, fn func(Tag) error) error {
+	return iam.EachRoleTagCtx(context.Background(), roleName, fn)
+}
+
+// EachRoleTagCtx is EachRoleTag, bounding total iteration latency,
+// including retries across every page, by ctx.
+func (iam *IAM) EachRoleTagCtx(ctx context.Context, roleName string, fn func(Tag) error) error {
+	marker := ""
+	for {
+		resp, err := iam.ListRoleTagsCtx(ctx, roleName, marker, 0)
+		if err != nil {
+			return err
+		}
+		for _, t := range resp.Tags {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+		if !resp.IsTruncated {
+			return nil
+		}
+		marker = resp.Marker
+	}
+}